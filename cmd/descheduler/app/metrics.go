@@ -0,0 +1,43 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
+	"k8s.io/klog"
+)
+
+// disableMetrics backs the --disable-metrics flag.
+var disableMetrics bool
+
+// AddMetricsFlags registers the --disable-metrics flag alongside the descheduler's other flags.
+func AddMetricsFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&disableMetrics, "disable-metrics", false, "Disable the /metrics Prometheus endpoint.")
+}
+
+// RegisterMetricsHandler mounts the Prometheus /metrics endpoint on mux, unless
+// --disable-metrics was set.
+func RegisterMetricsHandler(mux *http.ServeMux) {
+	if disableMetrics {
+		klog.V(1).Info("Metrics endpoint disabled via --disable-metrics")
+		return
+	}
+	mux.Handle("/metrics", promhttp.Handler())
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestAddMetricsFlagsDisablesHandler(t *testing.T) {
+	disableMetrics = false
+	defer func() { disableMetrics = false }()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddMetricsFlags(flags)
+	if err := flags.Parse([]string{"--disable-metrics"}); err != nil {
+		t.Fatalf("unexpected error parsing flags: %v", err)
+	}
+	if !disableMetrics {
+		t.Fatalf("expected --disable-metrics to set disableMetrics to true")
+	}
+
+	mux := http.NewServeMux()
+	RegisterMetricsHandler(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected /metrics to be unregistered when disabled, got status %d", rec.Code)
+	}
+}
+
+func TestRegisterMetricsHandlerMountsMetrics(t *testing.T) {
+	disableMetrics = false
+	defer func() { disableMetrics = false }()
+
+	mux := http.NewServeMux()
+	RegisterMetricsHandler(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected /metrics to be mounted, got status %d", rec.Code)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NodeWatcher keeps an up-to-date list of the cluster's nodes by watching Node add/remove
+// events, so each pass of a running reconciliation loop reads the current node set from the
+// watch cache instead of re-Listing the cluster. A Node event does not itself start a pass.
+type NodeWatcher struct {
+	informer cache.SharedIndexInformer
+}
+
+// NewNodeWatcher builds a NodeWatcher backed by a Node informer with the given resync
+// period. Call Start before the first call to Nodes.
+func NewNodeWatcher(client clientset.Interface, resync time.Duration) *NodeWatcher {
+	factory := informers.NewSharedInformerFactory(client, resync)
+	return &NodeWatcher{informer: factory.Core().V1().Nodes().Informer()}
+}
+
+// Start runs the underlying informer until ctx is done and blocks until its cache has synced.
+func (w *NodeWatcher) Start(ctx context.Context) {
+	go w.informer.Run(ctx.Done())
+	cache.WaitForCacheSync(ctx.Done(), w.informer.HasSynced)
+}
+
+// Nodes returns the informer's current view of the cluster's nodes.
+func (w *NodeWatcher) Nodes() []*v1.Node {
+	objs := w.informer.GetStore().List()
+	nodes := make([]*v1.Node, 0, len(objs))
+	for _, obj := range objs {
+		if node, ok := obj.(*v1.Node); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// DeschedulerServer holds the configuration the descheduler's strategies run against.
+type DeschedulerServer struct {
+	Client                    clientset.Interface
+	DryRun                    bool
+	EvictLocalStoragePods     bool
+	MaxNoOfPodsToEvictPerNode int
+
+	// PlanOutput is where a dry-run strategy writes its structured eviction plan: a file
+	// path (bound by AddPlanFlags to --plan-output), or empty for stdout.
+	PlanOutput string
+
+	drainer *evictions.Drainer
+}
+
+// Drainer returns this server's evictions.Drainer, building it on first use. The same
+// instance - and its in-flight TTL cache - is reused for every strategy run for the life of
+// the process, instead of being rebuilt (and its cache discarded) on each run.
+func (ds *DeschedulerServer) Drainer(policyGroupVersion string) *evictions.Drainer {
+	if ds.drainer == nil {
+		ds.drainer = evictions.NewDrainer(ds.Client, policyGroupVersion, ds.DryRun, ds.EvictLocalStoragePods)
+	}
+	return ds.drainer
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+)
+
+// AddPlanFlags registers the --plan-output flag alongside the descheduler's other flags,
+// binding it directly into ds.PlanOutput. When set together with --dry-run, strategies write
+// their structured eviction plan to the given path (or to stdout when left empty) instead of
+// only logging what they would do.
+func AddPlanFlags(flags *pflag.FlagSet, ds *options.DeschedulerServer) {
+	flags.StringVar(&ds.PlanOutput, "plan-output", "", "File to write the dry-run eviction plan to (JSON, or YAML if the path ends in .yaml/.yml); defaults to stdout.")
+}
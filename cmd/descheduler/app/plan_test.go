@@ -0,0 +1,39 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+)
+
+func TestAddPlanFlagsBindsDeschedulerServer(t *testing.T) {
+	ds := &options.DeschedulerServer{}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddPlanFlags(flags, ds)
+	if err := flags.Parse([]string{"--plan-output=/tmp/plan.json"}); err != nil {
+		t.Fatalf("unexpected error parsing flags: %v", err)
+	}
+
+	if ds.PlanOutput != "/tmp/plan.json" {
+		t.Fatalf("expected --plan-output to set ds.PlanOutput, got %q", ds.PlanOutput)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// AddLeaderElectionFlags registers the --leader-elect and --reconcile-interval flags
+// alongside the descheduler's other flags. When leader-elect is set, RunReconciler only
+// runs its reconciliation loop while holding the coordination.k8s.io Lease, so multiple
+// descheduler replicas can run HA without colliding.
+func AddLeaderElectionFlags(flags *pflag.FlagSet, leaderElect *bool, reconcileInterval *time.Duration) {
+	flags.BoolVar(leaderElect, "leader-elect", false, "Run the reconciliation loop only while holding a leader-election Lease.")
+	flags.DurationVar(reconcileInterval, "reconcile-interval", 10*time.Minute, "How often to re-run the descheduler's strategies; 0 without --leader-elect runs a single pass and exits.")
+}
+
+// RunReconciler calls runOnce immediately and then every interval for as long as ctx is
+// open. When leaderElect is true, it first acquires the lockNamespace/lockName Lease via
+// client-go's leaderelection package and only reconciles while holding it; RunReconciler
+// returns once that election's context ends (e.g. the lease is lost or ctx is canceled).
+func RunReconciler(ctx context.Context, client clientset.Interface, leaderElect bool, lockNamespace, lockName string, interval time.Duration, runOnce func(ctx context.Context)) error {
+	if interval <= 0 {
+		return fmt.Errorf("reconcile interval must be positive, got %s", interval)
+	}
+
+	if !leaderElect {
+		reconcileLoop(ctx, interval, runOnce)
+		return nil
+	}
+
+	id, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	id = id + "_" + string(uuid.NewUUID())
+
+	lock, err := resourcelock.New(
+		resourcelock.LeasesResourceLock,
+		lockNamespace,
+		lockName,
+		client.CoreV1(),
+		client.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: id},
+	)
+	if err != nil {
+		return err
+	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: defaultLeaseDuration,
+		RenewDeadline: defaultRenewDeadline,
+		RetryPeriod:   defaultRetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				klog.Infof("%s: acquired leadership, starting reconciliation loop", id)
+				reconcileLoop(leaderCtx, interval, runOnce)
+			},
+			OnStoppedLeading: func() {
+				klog.Infof("%s: lost leadership", id)
+			},
+		},
+	})
+	return nil
+}
+
+// reconcileLoop runs runOnce immediately, then again every interval until ctx is done.
+func reconcileLoop(ctx context.Context, interval time.Duration, runOnce func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	runOnce(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runOnce(ctx)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRunReconcilerRejectsNonPositiveInterval(t *testing.T) {
+	for _, leaderElect := range []bool{false, true} {
+		err := RunReconciler(context.Background(), &fake.Clientset{}, leaderElect, "ns", "lock", 0, func(context.Context) {})
+		if err == nil {
+			t.Errorf("expected RunReconciler to reject a non-positive interval with leaderElect=%v, got nil error", leaderElect)
+		}
+	}
+}
+
+func TestRunReconcilerRunsOnEveryTick(t *testing.T) {
+	var calls int32
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Millisecond)
+	defer cancel()
+
+	err := RunReconciler(ctx, &fake.Clientset{}, false, "ns", "lock", 10*time.Millisecond, func(context.Context) {
+		atomic.AddInt32(&calls, 1)
+	})
+	if err != nil {
+		t.Fatalf("RunReconciler returned an error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got < 2 {
+		t.Fatalf("expected runOnce to be called more than once before ctx expired, got %d", got)
+	}
+}
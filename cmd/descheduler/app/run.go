@@ -0,0 +1,88 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// RunMetricsServer starts an HTTP server on addr that serves /metrics (unless
+// --disable-metrics was passed via AddMetricsFlags), running alongside the descheduler's own
+// run rather than blocking it.
+func RunMetricsServer(addr string) {
+	mux := http.NewServeMux()
+	RegisterMetricsHandler(mux)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			klog.Errorf("metrics server exited: %v", err)
+		}
+	}()
+}
+
+// runDuplicatePods builds a fresh utils.NodePodEvictedCount for nodes and runs
+// RemoveDuplicatePods against it.
+func runDuplicatePods(ctx context.Context, ds *options.DeschedulerServer, strategy api.DeschedulerStrategy, policyGroupVersion string, nodes []*v1.Node) {
+	nodePodCount := utils.NodePodEvictedCount{}
+	for _, node := range nodes {
+		nodePodCount[node] = 0
+	}
+	strategies.RemoveDuplicatePods(ctx, ds, strategy, policyGroupVersion, nodes, nodePodCount)
+}
+
+// Run starts the metrics server and runs RemoveDuplicatePods. With leaderElect false and
+// reconcileInterval zero it does a single pass over a fresh List of the cluster's nodes, as
+// the descheduler has always done. Otherwise it runs continuously via RunReconciler - under
+// leader election when leaderElect is set - taking each pass's node list from a NodeWatcher,
+// so DuplicatePodCount.Recalculate sees the cluster's current node count on every pass. A
+// Node event does not itself start a pass.
+func Run(ctx context.Context, ds *options.DeschedulerServer, strategy api.DeschedulerStrategy, policyGroupVersion string, metricsAddr string, leaderElect bool, lockNamespace, lockName string, reconcileInterval time.Duration) error {
+	RunMetricsServer(metricsAddr)
+
+	if !leaderElect && reconcileInterval <= 0 {
+		nodeList, err := ds.Client.CoreV1().Nodes().List(metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		nodes := make([]*v1.Node, 0, len(nodeList.Items))
+		for i := range nodeList.Items {
+			nodes = append(nodes, &nodeList.Items[i])
+		}
+		runDuplicatePods(ctx, ds, strategy, policyGroupVersion, nodes)
+		return nil
+	}
+
+	watcher := NewNodeWatcher(ds.Client, reconcileInterval)
+	watcher.Start(ctx)
+
+	runOnce := func(ctx context.Context) {
+		runDuplicatePods(ctx, ds, strategy, policyGroupVersion, watcher.Nodes())
+	}
+
+	return RunReconciler(ctx, ds.Client, leaderElect, lockNamespace, lockName, reconcileInterval, runOnce)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+// DeschedulerStrategy holds the configuration for a single descheduler strategy, e.g.
+// RemoveDuplicatePods.
+type DeschedulerStrategy struct {
+	Enabled bool
+	Weight  int
+	Params  StrategyParameters
+}
+
+// StrategyParameters holds the parameters for all descheduler strategies; a given strategy
+// only reads the fields relevant to it.
+type StrategyParameters struct {
+	RemoveDuplicates *RemoveDuplicates
+
+	// PodSelectionPolicy picks which of a creator's duplicate pods a strategy evicts first,
+	// by name (see strategies.PodSelectorForPolicy). Leave empty to keep the API's own order.
+	PodSelectionPolicy string
+}
+
+// RemoveDuplicates configures the RemoveDuplicatePods strategy.
+type RemoveDuplicates struct {
+	// TopologyKeys groups nodes into topology domains by these node label keys (e.g.
+	// "topology.kubernetes.io/zone") so duplicates are balanced per domain instead of across
+	// the whole cluster. Leave empty to keep the original cluster-wide behavior.
+	TopologyKeys []string
+}
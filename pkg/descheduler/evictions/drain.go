@@ -0,0 +1,215 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// PodOutcome describes why the Drainer did, or did not, evict a given pod.
+type PodOutcome string
+
+const (
+	// Evicted means the pod's eviction request succeeded (or would have, under dry-run).
+	Evicted PodOutcome = "evicted"
+	// SkippedByFilter means the pod is a DaemonSet/mirror/static/terminating pod (or a
+	// local-storage pod, unless EvictLocalStoragePods is set) the Drainer never attempts to evict.
+	SkippedByFilter PodOutcome = "skipped-by-filter"
+	// SkippedInFlight means a previous Drain call already evicted this pod recently and
+	// it is still sitting in the TTL cache, so it was not re-evicted.
+	SkippedInFlight PodOutcome = "skipped-in-flight"
+	// BlockedByPDB means every attempt to evict the pod was rejected by a PodDisruptionBudget.
+	BlockedByPDB PodOutcome = "blocked-by-pdb"
+	// Error means eviction was attempted and failed for a reason other than a PDB.
+	Error PodOutcome = "error"
+)
+
+// PodResult records what happened when the Drainer tried to evict a single pod.
+type PodResult struct {
+	Pod     *v1.Pod
+	Outcome PodOutcome
+	Err     error
+}
+
+// Result is the aggregate outcome of a single Drain call.
+type Result struct {
+	Pods []PodResult
+}
+
+// CountByOutcome returns how many pods in the result ended up with the given outcome.
+func (r Result) CountByOutcome(outcome PodOutcome) int {
+	count := 0
+	for _, p := range r.Pods {
+		if p.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+const (
+	defaultMaxPDBRetries  = 3
+	defaultInitialBackoff = 1 * time.Second
+	defaultInFlightTTL    = 5 * time.Minute
+)
+
+// Drainer evicts pods the way a machine drain controller would: it filters out pods that
+// should never be evicted, retries PDB-blocked evictions with exponential backoff up to a
+// limit, and remembers recently-evicted pod UIDs so a strategy that runs again before the
+// apiserver finishes deleting a pod doesn't issue a second, redundant eviction for it.
+type Drainer struct {
+	Client                clientset.Interface
+	PolicyGroupVersion    string
+	DryRun                bool
+	EvictLocalStoragePods bool
+	MaxPDBRetries         int
+	InitialBackoff        time.Duration
+	InFlightTTL           time.Duration
+
+	mu       sync.Mutex
+	inFlight map[types.UID]time.Time
+}
+
+// NewDrainer returns a Drainer with the package's default PDB-retry and in-flight-cache
+// settings; callers can override any of them on the returned value before calling Drain.
+func NewDrainer(client clientset.Interface, policyGroupVersion string, dryRun, evictLocalStoragePods bool) *Drainer {
+	return &Drainer{
+		Client:                client,
+		PolicyGroupVersion:    policyGroupVersion,
+		DryRun:                dryRun,
+		EvictLocalStoragePods: evictLocalStoragePods,
+		MaxPDBRetries:         defaultMaxPDBRetries,
+		InitialBackoff:        defaultInitialBackoff,
+		InFlightTTL:           defaultInFlightTTL,
+		inFlight:              map[types.UID]time.Time{},
+	}
+}
+
+// Drain attempts to evict each of the given pods and returns a per-pod outcome. Pods are
+// processed in order; a pod blocked by a PDB is retried with exponential backoff before
+// being reported as BlockedByPDB. ctx governs that backoff: canceling it (e.g. a reconcile
+// loop shutting down) aborts the retry immediately instead of blocking until it elapses.
+func (d *Drainer) Drain(ctx context.Context, pods []*v1.Pod) Result {
+	result := Result{Pods: make([]PodResult, 0, len(pods))}
+	for _, pod := range pods {
+		outcome, err := d.drainOne(ctx, pod)
+		result.Pods = append(result.Pods, PodResult{Pod: pod, Outcome: outcome, Err: err})
+	}
+	return result
+}
+
+func (d *Drainer) drainOne(ctx context.Context, pod *v1.Pod) (PodOutcome, error) {
+	if !isEvictable(pod, d.EvictLocalStoragePods) {
+		return SkippedByFilter, nil
+	}
+
+	if pod.UID != "" && d.recentlyEvicted(pod.UID) {
+		klog.V(1).Infof("Skipping pod %#v, already evicted within the last %s", pod.Name, d.InFlightTTL)
+		return SkippedInFlight, nil
+	}
+
+	backoff := d.InitialBackoff
+	var lastErr error
+	for attempt := 0; attempt <= d.MaxPDBRetries; attempt++ {
+		success, err := EvictPod(d.Client, pod, d.PolicyGroupVersion, d.DryRun)
+		if success {
+			if pod.UID != "" {
+				d.markEvicted(pod.UID)
+			}
+			return Evicted, nil
+		}
+
+		lastErr = err
+		if !apierrors.IsTooManyRequests(err) {
+			return Error, err
+		}
+		if attempt == d.MaxPDBRetries {
+			break
+		}
+
+		klog.V(1).Infof("Eviction of pod %#v blocked by a PodDisruptionBudget, retrying in %s (%d/%d)", pod.Name, backoff, attempt+1, d.MaxPDBRetries)
+		select {
+		case <-ctx.Done():
+			return Error, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return BlockedByPDB, lastErr
+}
+
+// recentlyEvicted reports whether pod uid was marked evicted within InFlightTTL, pruning
+// any expired entries it encounters along the way.
+func (d *Drainer) recentlyEvicted(uid types.UID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	evictedAt, ok := d.inFlight[uid]
+	if !ok {
+		return false
+	}
+	if time.Since(evictedAt) > d.InFlightTTL {
+		delete(d.inFlight, uid)
+		return false
+	}
+	return true
+}
+
+func (d *Drainer) markEvicted(uid types.UID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.inFlight[uid] = time.Now()
+}
+
+// isEvictable reports whether a pod is one the Drainer will ever attempt to evict: not a
+// DaemonSet or mirror/static pod, not already terminating, and, unless
+// evictLocalStoragePods is set, without hostPath or emptyDir volumes.
+func isEvictable(pod *v1.Pod, evictLocalStoragePods bool) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	if _, ok := pod.Annotations[v1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	for _, ownerRef := range pod.OwnerReferences {
+		if ownerRef.Kind == "DaemonSet" {
+			return false
+		}
+	}
+	if !evictLocalStoragePods && hasLocalStorage(pod) {
+		return false
+	}
+	return true
+}
+
+func hasLocalStorage(pod *v1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil || volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+func testPod(name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+	}
+}
+
+func TestDrainerRetriesThenBlockedByPDB(t *testing.T) {
+	attempts := 0
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		attempts++
+		return true, nil, apierrors.NewTooManyRequests("blocked by pdb", 1)
+	})
+
+	drainer := NewDrainer(fakeClient, "v1", false, false)
+	drainer.MaxPDBRetries = 2
+	drainer.InitialBackoff = time.Millisecond
+
+	result := drainer.Drain(context.Background(), []*v1.Pod{testPod("p1")})
+
+	if got := result.Pods[0].Outcome; got != BlockedByPDB {
+		t.Fatalf("expected outcome %v, got %v", BlockedByPDB, got)
+	}
+	if attempts != drainer.MaxPDBRetries+1 {
+		t.Fatalf("expected %d eviction attempts, got %d", drainer.MaxPDBRetries+1, attempts)
+	}
+}
+
+func TestDrainerSkipsRecentlyEvictedPod(t *testing.T) {
+	attempts := 0
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		attempts++
+		return true, nil, nil
+	})
+
+	drainer := NewDrainer(fakeClient, "v1", false, false)
+	pod := testPod("p1")
+	ctx := context.Background()
+
+	first := drainer.Drain(ctx, []*v1.Pod{pod})
+	if first.Pods[0].Outcome != Evicted {
+		t.Fatalf("expected first Drain call to evict the pod, got %v", first.Pods[0].Outcome)
+	}
+
+	second := drainer.Drain(ctx, []*v1.Pod{pod})
+	if second.Pods[0].Outcome != SkippedInFlight {
+		t.Fatalf("expected second Drain call to skip the pod as in-flight, got %v", second.Pods[0].Outcome)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected only 1 eviction attempt, got %d", attempts)
+	}
+
+	drainer.InFlightTTL = -time.Second // force the cache entry to look expired
+	third := drainer.Drain(ctx, []*v1.Pod{pod})
+	if third.Pods[0].Outcome != Evicted {
+		t.Fatalf("expected the pod to be evicted again once its TTL expired, got %v", third.Pods[0].Outcome)
+	}
+}
+
+func TestDrainerAbortsBackoffWhenContextCanceled(t *testing.T) {
+	attempts := 0
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		attempts++
+		return true, nil, apierrors.NewTooManyRequests("blocked by pdb", 1)
+	})
+
+	drainer := NewDrainer(fakeClient, "v1", false, false)
+	drainer.MaxPDBRetries = 5
+	drainer.InitialBackoff = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := drainer.Drain(ctx, []*v1.Pod{testPod("p1")})
+	if got := result.Pods[0].Outcome; got != Error {
+		t.Fatalf("expected outcome %v once ctx is canceled, got %v", Error, got)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected the retry loop to stop after the first attempt, got %d attempts", attempts)
+	}
+}
+
+func TestIsEvictableFilters(t *testing.T) {
+	daemonset := testPod("ds")
+	daemonset.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet"}}
+	if isEvictable(daemonset, false) {
+		t.Error("expected a DaemonSet pod to not be evictable")
+	}
+
+	mirror := testPod("mirror")
+	mirror.Annotations = map[string]string{v1.MirrorPodAnnotationKey: ""}
+	if isEvictable(mirror, false) {
+		t.Error("expected a mirror pod to not be evictable")
+	}
+
+	terminating := testPod("terminating")
+	now := metav1.Now()
+	terminating.DeletionTimestamp = &now
+	if isEvictable(terminating, false) {
+		t.Error("expected a terminating pod to not be evictable")
+	}
+
+	if !isEvictable(testPod("plain"), false) {
+		t.Error("expected a plain pod to be evictable")
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exposes the descheduler's Prometheus metrics. Strategies record through
+// the package-level functions below rather than touching the underlying collectors, so the
+// metric names and labels stay consistent across strategies.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "descheduler"
+
+// Eviction outcomes recorded against PodsEvicted's "result" label.
+const (
+	ResultEvicted         = "evicted"
+	ResultSkippedByFilter = "skipped-by-filter"
+	ResultBlockedByPDB    = "blocked-by-pdb"
+	ResultError           = "error"
+)
+
+var (
+	// PodsEvicted counts every eviction attempt a strategy makes, labeled by its outcome.
+	PodsEvicted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pods_evicted_total",
+		Help:      "Number of pods evicted, or attempted to be evicted, by each strategy.",
+	}, []string{"strategy", "namespace", "node", "result"})
+
+	// StrategyDuration tracks how long each strategy's single pass over the cluster takes.
+	StrategyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "strategy_duration_seconds",
+		Help:      "Time taken by a strategy to complete one run.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"strategy"})
+
+	// DuplicatePods reports the current number of duplicate pods RemoveDuplicatePods has
+	// found for a given creator on a given node, as of its last run.
+	DuplicatePods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "duplicate_pods",
+		Help:      "Number of duplicate pods found for a creator on a node.",
+	}, []string{"creator", "node"})
+)
+
+func init() {
+	prometheus.MustRegister(PodsEvicted, StrategyDuration, DuplicatePods)
+}
+
+// RecordEviction increments PodsEvicted for a single pod outcome.
+func RecordEviction(strategy, namespace, node, result string) {
+	PodsEvicted.WithLabelValues(strategy, namespace, node, result).Inc()
+}
+
+// ObserveStrategyDuration records how long a strategy run took, measured from start.
+func ObserveStrategyDuration(strategy string, start time.Time) {
+	StrategyDuration.WithLabelValues(strategy).Observe(time.Since(start).Seconds())
+}
+
+// SetDuplicatePods sets the current duplicate pod count for a creator on a node.
+func SetDuplicatePods(creator, node string, count int) {
+	DuplicatePods.WithLabelValues(creator, node).Set(float64(count))
+}
@@ -17,8 +17,11 @@ limitations under the License.
 package strategies
 
 import (
+	"context"
+	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
@@ -27,13 +30,37 @@ import (
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/metrics"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
-//type creator string
+// outcomeMetricsResult maps a Drainer outcome onto the metrics.PodsEvicted "result" label.
+func outcomeMetricsResult(outcome evictions.PodOutcome) string {
+	switch outcome {
+	case evictions.Evicted:
+		return metrics.ResultEvicted
+	case evictions.BlockedByPDB:
+		return metrics.ResultBlockedByPDB
+	case evictions.SkippedByFilter, evictions.SkippedInFlight:
+		return metrics.ResultSkippedByFilter
+	default:
+		return metrics.ResultError
+	}
+}
+
+// type creator string
 type DuplicatePodsMap map[string][]*v1.Pod
 type DuplicateNodePodsMap map[*v1.Node]DuplicatePodsMap
+
+// domainCreatorKey identifies a single creator (namespace/kind/name) within a single
+// topology domain, so Max/Min/Remainder can be computed per domain instead of always
+// across the whole cluster.
+type domainCreatorKey struct {
+	domain  string
+	creator string
+}
+
 type DuplicatePodCount struct {
 	Total     int
 	Nodes     int
@@ -53,41 +80,101 @@ func (dpc *DuplicatePodCount) Recalculate() {
 // RemoveDuplicatePods removes the duplicate pods on node. This strategy evicts all duplicate pods on node.
 // A pod is said to be a duplicate of other if both of them are from same creator, kind and are within the same
 // namespace. As of now, this strategy won't evict daemonsets, mirror pods, critical pods and pods with local storages.
-func RemoveDuplicatePods(ds *options.DeschedulerServer, strategy api.DeschedulerStrategy, policyGroupVersion string, nodes []*v1.Node, nodepodCount utils.NodePodEvictedCount) {
+// If strategy.Params.RemoveDuplicates.TopologyKeys is set, duplicates are instead computed per topology domain
+// (nodes grouped by those node label keys, e.g. zone or region) rather than across the whole cluster, so that
+// RemoveDuplicatePods can honor topologySpreadConstraints-style goals that may have drifted since admission.
+// strategy.Params.PodSelectionPolicy picks which of a creator's duplicates are evicted first; it defaults to
+// the order the API returned them in.
+func RemoveDuplicatePods(ctx context.Context, ds *options.DeschedulerServer, strategy api.DeschedulerStrategy, policyGroupVersion string, nodes []*v1.Node, nodepodCount utils.NodePodEvictedCount) {
 	if !strategy.Enabled {
 		return
 	}
-	deleteDuplicatePods(ds.Client, policyGroupVersion, nodes, ds.DryRun, nodepodCount, ds.MaxNoOfPodsToEvictPerNode, ds.EvictLocalStoragePods)
+	var topologyKeys []string
+	if strategy.Params.RemoveDuplicates != nil {
+		topologyKeys = strategy.Params.RemoveDuplicates.TopologyKeys
+	}
+	selector := PodSelectorForPolicy(strategy.Params.PodSelectionPolicy)
+	deleteDuplicatePods(ctx, ds.Client, nodes, ds.DryRun, nodepodCount, ds.MaxNoOfPodsToEvictPerNode, ds.EvictLocalStoragePods, topologyKeys, ds.PlanOutput, selector, ds.Drainer(policyGroupVersion))
+}
+
+// topologyDomain returns the identifier of the topology domain a node belongs to, given
+// the topology keys configured for the strategy. When no topology keys are configured,
+// every node is lumped into a single "" domain, which reproduces the strategy's original
+// cluster-wide (rather than per-topology-bucket) ratio math.
+func topologyDomain(node *v1.Node, topologyKeys []string) string {
+	if len(topologyKeys) == 0 {
+		return ""
+	}
+	values := make([]string, 0, len(topologyKeys))
+	for _, key := range topologyKeys {
+		values = append(values, key+"="+node.Labels[key])
+	}
+	return strings.Join(values, ",")
 }
 
 // deleteDuplicatePods evicts the pod from node and returns the count of evicted pods.
-func deleteDuplicatePods(client clientset.Interface, policyGroupVersion string, nodes []*v1.Node, dryRun bool, nodepodCount utils.NodePodEvictedCount, maxPodsToEvict int, evictLocalStoragePods bool) int {
+// When topologyKeys is non-empty, duplicates are computed per topology domain (the set of
+// nodes sharing the same values for those keys) rather than across the whole cluster, so
+// that surplus pods are evicted from over-full domains rather than over-full nodes alone.
+// drainer is expected to be long-lived across calls (see options.DeschedulerServer.Drainer)
+// so its in-flight eviction cache actually has a chance to prevent redundant evictions. ctx
+// is threaded down to the drainer so a canceled reconcile loop aborts PDB-retry backoff
+// instead of blocking until it elapses.
+func deleteDuplicatePods(ctx context.Context, client clientset.Interface, nodes []*v1.Node, dryRun bool, nodepodCount utils.NodePodEvictedCount, maxPodsToEvict int, evictLocalStoragePods bool, topologyKeys []string, planOutput string, selector PodSelector, drainer *evictions.Drainer) int {
+	defer metrics.ObserveStrategyDuration("RemoveDuplicatePods", time.Now())
+
 	podsEvicted := 0
-	podCounts := map[string]*DuplicatePodCount{}
+	podCounts := map[domainCreatorKey]*DuplicatePodCount{}
 	nodePods := DuplicateNodePodsMap{}
-	nodeCount := len(nodes)
+	nodeDomain := map[*v1.Node]string{}
+	domainNodeCount := map[string]int{}
 
+	var plan *Plan
+	if dryRun {
+		plan = &Plan{Strategy: "RemoveDuplicatePods"}
+	}
+	buckets := map[domainCreatorKey]*PlanBucket{}
+
+	// First pass: only accumulate each creator's Total across the domain, so Max/Min can be
+	// computed domain-wide. Eviction itself still happens per node, below, against each
+	// node's own pods for that creator - a domain never has its pods flattened into one list.
 	for _, node := range nodes {
 		klog.V(1).Infof("Processing node: %#v", node.Name)
+		domain := topologyDomain(node, topologyKeys)
+		nodeDomain[node] = domain
+		domainNodeCount[domain]++
+
 		dpm := ListDuplicatePodsOnANode(client, node, evictLocalStoragePods)
 		nodePods[node] = dpm
 
 		for creator, pods := range dpm {
-			if _, ok := podCounts[creator]; !ok {
-				podCounts[creator] = &DuplicatePodCount{}
+			metrics.SetDuplicatePods(creator, node.Name, len(pods))
+
+			key := domainCreatorKey{domain: domain, creator: creator}
+			if _, ok := podCounts[key]; !ok {
+				podCounts[key] = &DuplicatePodCount{}
 			}
-			podCounts[creator].Total += len(pods)
-			podCounts[creator].Nodes = nodeCount
-			podCounts[creator].Recalculate()
+			podCounts[key].Total += len(pods)
 		}
 	}
 
+	for key, pc := range podCounts {
+		pc.Nodes = domainNodeCount[key.domain]
+		pc.Recalculate()
+	}
+
+	// Second pass: evict each node's own excess over the domain-wide Max.
 	for _, node := range nodes {
-		klog.V(1).Infof("Processing node: %#v", node.Name)
+		domain := nodeDomain[node]
 		dpm := nodePods[node]
 
 		for creator, pods := range dpm {
-			pc := podCounts[creator]
+			pc := podCounts[domainCreatorKey{domain: domain, creator: creator}]
+
+			if selector != nil {
+				pods = selector.Select(pods)
+			}
+
 			// podsToEvict := len(pods) - pc.Min
 			podsToEvict := len(pods) - pc.Max
 
@@ -104,22 +191,53 @@ func deleteDuplicatePods(client clientset.Interface, policyGroupVersion string,
 			klog.V(1).Infof("%#v", creator)
 			// i = 0 does not evict the first pod
 
+			key := domainCreatorKey{domain: domain, creator: creator}
+			var bucket *PlanBucket
+			if plan != nil {
+				bucket = buckets[key]
+				if bucket == nil {
+					bucket = &PlanBucket{Domain: domain, Creator: creator, Total: pc.Total, Nodes: pc.Nodes, Max: pc.Max, Min: pc.Min, Remainder: pc.Remainder}
+					buckets[key] = bucket
+				}
+			}
+
 			for i := 0; i < podsToEvict; i++ {
 				if maxPodsToEvict > 0 && nodepodCount[node]+1 > maxPodsToEvict {
 					break
 				}
-				success, err := evictions.EvictPod(client, pods[i], policyGroupVersion, dryRun)
-				if !success {
-					klog.Infof("Error when evicting pod: %#v (%#v)", pods[i].Name, err)
-				} else {
+				result := drainer.Drain(ctx, []*v1.Pod{pods[i]})
+				outcome := result.Pods[0].Outcome
+				metrics.RecordEviction("RemoveDuplicatePods", pods[i].Namespace, node.Name, outcomeMetricsResult(outcome))
+				switch outcome {
+				case evictions.Evicted:
 					nodepodCount[node]++
-					klog.V(1).Infof("Evicted pod: %#v (%#v)", pods[i].Name, err)
+					klog.V(1).Infof("Evicted pod: %#v", pods[i].Name)
+					if bucket != nil {
+						reason := fmt.Sprintf("%d pods of creator %s on node %s exceed the max of %d allowed in domain %q", len(pods), creator, node.Name, pc.Max, domain)
+						bucket.Evictions = append(bucket.Evictions, PlanEviction{
+							Pod:    pods[i].Namespace + "/" + pods[i].Name,
+							Node:   node.Name,
+							Reason: reason,
+						})
+					}
+				default:
+					klog.Infof("Did not evict pod %#v: %s (%#v)", pods[i].Name, outcome, result.Pods[0].Err)
 				}
 			}
 		}
 
 		podsEvicted += nodepodCount[node]
+	}
 
+	if plan != nil {
+		for _, bucket := range buckets {
+			if len(bucket.Evictions) > 0 {
+				plan.Buckets = append(plan.Buckets, *bucket)
+			}
+		}
+		if err := WritePlan(plan, planOutput); err != nil {
+			klog.Errorf("Error writing dry-run plan: %v", err)
+		}
 	}
 
 	return podsEvicted
@@ -17,7 +17,11 @@ limitations under the License.
 package strategies
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
@@ -26,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/test"
 )
@@ -222,10 +227,106 @@ func TestFindDuplicatePods(t *testing.T) {
 			}
 			return true, nil, fmt.Errorf("Wrong node: %v", getAction.GetName())
 		})
-		podsEvicted := deleteDuplicatePods(fakeClient, "v1", testCase.nodes, false, npe, testCase.maxPodsToEvict, false)
+		drainer := evictions.NewDrainer(fakeClient, "v1", false, false)
+		podsEvicted := deleteDuplicatePods(context.Background(), fakeClient, testCase.nodes, false, npe, testCase.maxPodsToEvict, false, nil, "", nil, drainer)
 		if podsEvicted != testCase.expectedEvictedPodCount {
 			t.Errorf("Test error for description: %s. Expected evicted pods count %v, got %v", testCase.description, testCase.expectedEvictedPodCount, podsEvicted)
 		}
 	}
 
 }
+
+func TestTopologyDomain(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10)
+	n1.Labels = map[string]string{"topology.kubernetes.io/zone": "zone-a"}
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10)
+	n2.Labels = map[string]string{"topology.kubernetes.io/zone": "zone-b"}
+
+	if got := topologyDomain(n1, nil); got != "" {
+		t.Errorf("expected empty domain when no topology keys are configured, got %q", got)
+	}
+
+	zoneKeys := []string{"topology.kubernetes.io/zone"}
+	if d1, d2 := topologyDomain(n1, zoneKeys), topologyDomain(n2, zoneKeys); d1 == d2 {
+		t.Errorf("expected nodes in different zones to resolve to different domains, both got %q", d1)
+	}
+	if d1, d1Again := topologyDomain(n1, zoneKeys), topologyDomain(n1, zoneKeys); d1 != d1Again {
+		t.Errorf("expected topologyDomain to be stable for the same node, got %q and %q", d1, d1Again)
+	}
+}
+
+// TestDeleteDuplicatePodsDryRunPlan exercises the dry-run plan output end to end: n1 has 3
+// pods of a creator and n2 has 8 (11 total over 2 nodes -> Max=6), so only n2's 2-pod excess
+// over Max should show up in the plan, never n1's pods.
+func TestDeleteDuplicatePodsDryRunPlan(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10)
+	n2 := test.BuildTestNode("n2", 4000, 4000, 20)
+
+	ownerRef := test.GetReplicaSetOwnerRefList()
+	pods := []v1.Pod{}
+	for i := 0; i < 3; i++ {
+		p := test.BuildTestPod(fmt.Sprintf("n1-%d", i), 100, 0, n1.Name)
+		p.Namespace = "dev"
+		p.ObjectMeta.OwnerReferences = ownerRef
+		pods = append(pods, *p)
+	}
+	for i := 0; i < 8; i++ {
+		p := test.BuildTestPod(fmt.Sprintf("n2-%d", i), 100, 0, n2.Name)
+		p.Namespace = "dev"
+		p.ObjectMeta.OwnerReferences = ownerRef
+		pods = append(pods, *p)
+	}
+
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		obj := &v1.PodList{}
+		for _, pod := range pods {
+			podFieldSet := fields.Set(map[string]string{
+				"spec.nodeName": pod.Spec.NodeName,
+				"status.phase":  string(pod.Status.Phase),
+			})
+			if action.(core.ListAction).GetListRestrictions().Fields.Matches(podFieldSet) {
+				obj.Items = append(obj.Items, *pod.DeepCopy())
+			}
+		}
+		return true, obj, nil
+	})
+	fakeClient.Fake.AddReactor("get", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		switch action.(core.GetAction).GetName() {
+		case n1.Name:
+			return true, n1, nil
+		case n2.Name:
+			return true, n2, nil
+		}
+		return true, nil, fmt.Errorf("wrong node")
+	})
+
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	npe := utils.NodePodEvictedCount{n1: 0, n2: 0}
+	drainer := evictions.NewDrainer(fakeClient, "v1", true, false)
+	deleteDuplicatePods(context.Background(), fakeClient, []*v1.Node{n1, n2}, true, npe, 0, false, nil, planPath, nil, drainer)
+
+	data, err := os.ReadFile(planPath)
+	if err != nil {
+		t.Fatalf("reading plan file: %v", err)
+	}
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		t.Fatalf("plan file is not valid JSON: %v", err)
+	}
+	if len(plan.Buckets) != 1 {
+		t.Fatalf("expected exactly 1 bucket, got %d: %+v", len(plan.Buckets), plan.Buckets)
+	}
+	bucket := plan.Buckets[0]
+	if bucket.Max != 6 || bucket.Min != 5 || bucket.Total != 11 {
+		t.Errorf("expected Max=6 Min=5 Total=11, got Max=%d Min=%d Total=%d", bucket.Max, bucket.Min, bucket.Total)
+	}
+	if len(bucket.Evictions) != 2 {
+		t.Fatalf("expected 2 planned evictions, got %d: %+v", len(bucket.Evictions), bucket.Evictions)
+	}
+	for _, eviction := range bucket.Evictions {
+		if eviction.Node != n2.Name {
+			t.Errorf("expected every planned eviction to be on %s, got %s", n2.Name, eviction.Node)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// PlanEviction is a single pod a dry-run strategy has decided it would evict.
+type PlanEviction struct {
+	Pod    string `json:"pod" yaml:"pod"`
+	Node   string `json:"node" yaml:"node"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// PlanBucket is the ratio math and resulting evictions for one creator within one
+// topology domain (or the whole cluster, when no topology keys are configured).
+type PlanBucket struct {
+	Domain    string         `json:"domain" yaml:"domain"`
+	Creator   string         `json:"creator" yaml:"creator"`
+	Total     int            `json:"total" yaml:"total"`
+	Nodes     int            `json:"nodes" yaml:"nodes"`
+	Max       int            `json:"max" yaml:"max"`
+	Min       int            `json:"min" yaml:"min"`
+	Remainder int            `json:"remainder" yaml:"remainder"`
+	Evictions []PlanEviction `json:"evictions" yaml:"evictions"`
+}
+
+// Plan is the structured dry-run report for a single strategy run.
+type Plan struct {
+	Strategy string       `json:"strategy" yaml:"strategy"`
+	Buckets  []PlanBucket `json:"buckets" yaml:"buckets"`
+}
+
+// WritePlan renders plan as JSON or YAML (chosen by the ".yaml"/".yml" suffix of path) and
+// writes it to path, or to stdout when path is empty.
+func WritePlan(plan *Plan, path string) error {
+	asYAML := strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")
+
+	var out []byte
+	var err error
+	if asYAML {
+		out, err = yaml.Marshal(plan)
+	} else {
+		out, err = json.MarshalIndent(plan, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("marshaling dry-run plan: %v", err)
+	}
+
+	if path == "" {
+		_, err = os.Stdout.Write(append(out, '\n'))
+		return err
+	}
+
+	return os.WriteFile(path, out, 0644)
+}
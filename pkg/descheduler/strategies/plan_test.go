@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func samplePlan() *Plan {
+	return &Plan{
+		Strategy: "RemoveDuplicatePods",
+		Buckets: []PlanBucket{
+			{
+				Domain: "", Creator: "dev/ReplicaSet/rs1", Total: 11, Nodes: 2, Max: 6, Min: 5, Remainder: 1,
+				Evictions: []PlanEviction{{Pod: "dev/p1", Node: "n2", Reason: "exceeds max"}},
+			},
+		},
+	}
+}
+
+func TestWritePlanJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := WritePlan(samplePlan(), path); err != nil {
+		t.Fatalf("WritePlan returned an error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading plan file: %v", err)
+	}
+
+	var got Plan
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("plan file is not valid JSON: %v", err)
+	}
+	if len(got.Buckets) != 1 || got.Buckets[0].Max != 6 {
+		t.Fatalf("unexpected plan contents: %+v", got)
+	}
+}
+
+func TestWritePlanYAMLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	if err := WritePlan(samplePlan(), path); err != nil {
+		t.Fatalf("WritePlan returned an error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading plan file: %v", err)
+	}
+
+	var got Plan
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("plan file is not valid YAML: %v", err)
+	}
+	if len(got.Buckets) != 1 || got.Buckets[0].Creator != "dev/ReplicaSet/rs1" {
+		t.Fatalf("unexpected plan contents: %+v", got)
+	}
+}
+
+func TestWritePlanDefaultsToStdout(t *testing.T) {
+	read, write, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("creating pipe: %v", err)
+	}
+
+	original := os.Stdout
+	os.Stdout = write
+	err = WritePlan(samplePlan(), "")
+	os.Stdout = original
+	write.Close()
+	if err != nil {
+		t.Fatalf("WritePlan returned an error: %v", err)
+	}
+
+	data := make([]byte, 4096)
+	n, _ := read.Read(data)
+	var got Plan
+	if err := json.Unmarshal(data[:n], &got); err != nil {
+		t.Fatalf("stdout output is not valid JSON: %v", err)
+	}
+	if len(got.Buckets) != 1 {
+		t.Fatalf("unexpected plan contents: %+v", got)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// PodSelector orders a creator's duplicate pods so that a strategy evicts the leading
+// podsToEvict entries of the returned slice. In other words, Select defines which
+// duplicates go first in line for eviction, not which ones are kept.
+type PodSelector interface {
+	Select(pods []*v1.Pod) []*v1.Pod
+}
+
+// YoungestFirstSelector evicts the most recently created pods first.
+type YoungestFirstSelector struct{}
+
+func (YoungestFirstSelector) Select(pods []*v1.Pod) []*v1.Pod {
+	sorted := sortedCopy(pods)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[j].CreationTimestamp.Before(&sorted[i].CreationTimestamp)
+	})
+	return sorted
+}
+
+// OldestFirstSelector evicts the longest-running pods first.
+type OldestFirstSelector struct{}
+
+func (OldestFirstSelector) Select(pods []*v1.Pod) []*v1.Pod {
+	sorted := sortedCopy(pods)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+	})
+	return sorted
+}
+
+// HighestRestartCountSelector evicts pods with the most container restarts first, on the
+// theory that a crash-looping pod is a better eviction candidate than a healthy one.
+type HighestRestartCountSelector struct{}
+
+func (HighestRestartCountSelector) Select(pods []*v1.Pod) []*v1.Pod {
+	sorted := sortedCopy(pods)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return restartCount(sorted[i]) > restartCount(sorted[j])
+	})
+	return sorted
+}
+
+// LowestPrioritySelector evicts the lowest PriorityClass pods first.
+type LowestPrioritySelector struct{}
+
+func (LowestPrioritySelector) Select(pods []*v1.Pod) []*v1.Pod {
+	sorted := sortedCopy(pods)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return podPriority(sorted[i]) < podPriority(sorted[j])
+	})
+	return sorted
+}
+
+// LeastRequestedResourcesSelector evicts the pods with the smallest CPU requests first, so
+// that reclaiming a given number of pods frees as little capacity as possible.
+type LeastRequestedResourcesSelector struct{}
+
+func (LeastRequestedResourcesSelector) Select(pods []*v1.Pod) []*v1.Pod {
+	sorted := sortedCopy(pods)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return requestedCPUMillis(sorted[i]) < requestedCPUMillis(sorted[j])
+	})
+	return sorted
+}
+
+// PodSelectorForPolicy returns the PodSelector registered under the given
+// api.StrategyParameters.PodSelectionPolicy name, or nil if policy is empty or
+// unrecognized, in which case callers should fall back to the API's own pod ordering.
+func PodSelectorForPolicy(policy string) PodSelector {
+	switch policy {
+	case "YoungestFirst":
+		return YoungestFirstSelector{}
+	case "OldestFirst":
+		return OldestFirstSelector{}
+	case "HighestRestartCount":
+		return HighestRestartCountSelector{}
+	case "LowestPriority":
+		return LowestPrioritySelector{}
+	case "LeastRequestedResources":
+		return LeastRequestedResourcesSelector{}
+	default:
+		return nil
+	}
+}
+
+func sortedCopy(pods []*v1.Pod) []*v1.Pod {
+	sorted := make([]*v1.Pod, len(pods))
+	copy(sorted, pods)
+	return sorted
+}
+
+func restartCount(pod *v1.Pod) int32 {
+	var total int32
+	for _, cs := range pod.Status.ContainerStatuses {
+		total += cs.RestartCount
+	}
+	return total
+}
+
+func podPriority(pod *v1.Pod) int32 {
+	if pod.Spec.Priority != nil {
+		return *pod.Spec.Priority
+	}
+	return 0
+}
+
+func requestedCPUMillis(pod *v1.Pod) int64 {
+	var total int64
+	for _, container := range pod.Spec.Containers {
+		total += container.Resources.Requests.Cpu().MilliValue()
+	}
+	return total
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestPodSelectorForPolicy(t *testing.T) {
+	if PodSelectorForPolicy("") != nil {
+		t.Errorf("expected no selector for an empty policy")
+	}
+	if PodSelectorForPolicy("Nonsense") != nil {
+		t.Errorf("expected no selector for an unrecognized policy")
+	}
+
+	for _, policy := range []string{"YoungestFirst", "OldestFirst", "HighestRestartCount", "LowestPriority", "LeastRequestedResources"} {
+		if PodSelectorForPolicy(policy) == nil {
+			t.Errorf("expected a selector to be registered for policy %q", policy)
+		}
+	}
+}
+
+func TestOldestYoungestFirstSelectors(t *testing.T) {
+	older := test.BuildTestPod("older", 100, 0, "n1")
+	older.CreationTimestamp = metav1.NewTime(older.CreationTimestamp.Time.Add(-time.Hour))
+	younger := test.BuildTestPod("younger", 100, 0, "n1")
+
+	pods := []*v1.Pod{younger, older}
+
+	oldestFirst := OldestFirstSelector{}.Select(pods)
+	if oldestFirst[0].Name != "older" {
+		t.Errorf("expected OldestFirstSelector to put %q first, got %q", "older", oldestFirst[0].Name)
+	}
+
+	youngestFirst := YoungestFirstSelector{}.Select(pods)
+	if youngestFirst[0].Name != "younger" {
+		t.Errorf("expected YoungestFirstSelector to put %q first, got %q", "younger", youngestFirst[0].Name)
+	}
+}
+
+func TestHighestRestartCountSelector(t *testing.T) {
+	quiet := test.BuildTestPod("quiet", 100, 0, "n1")
+	crashing := test.BuildTestPod("crashing", 100, 0, "n1")
+	crashing.Status.ContainerStatuses = []v1.ContainerStatus{{RestartCount: 5}}
+
+	sorted := HighestRestartCountSelector{}.Select([]*v1.Pod{quiet, crashing})
+	if sorted[0].Name != "crashing" {
+		t.Errorf("expected HighestRestartCountSelector to put %q first, got %q", "crashing", sorted[0].Name)
+	}
+}
+
+func TestLowestPrioritySelector(t *testing.T) {
+	high := test.BuildTestPod("high", 100, 0, "n1")
+	var highPriority int32 = 100
+	high.Spec.Priority = &highPriority
+
+	low := test.BuildTestPod("low", 100, 0, "n1")
+	var lowPriority int32 = 1
+	low.Spec.Priority = &lowPriority
+
+	sorted := LowestPrioritySelector{}.Select([]*v1.Pod{high, low})
+	if sorted[0].Name != "low" {
+		t.Errorf("expected LowestPrioritySelector to put %q first, got %q", "low", sorted[0].Name)
+	}
+}